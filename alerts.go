@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// AlertRule defines a quota threshold to watch on one interface and who to
+// notify when it fires. Direction "reset" ignores the thresholds and instead
+// fires whenever the interface's monthly counter rolls over.
+type AlertRule struct {
+	Name             string           `json:"name"`
+	Interface        string           `json:"interface"`
+	Direction        string           `json:"direction"`         // "sent" | "recv" | "total" | "reset"
+	ThresholdBytes   uint64           `json:"threshold_bytes"`   // absolute threshold; 0 disables
+	ThresholdPercent float64          `json:"threshold_percent"` // % of the interface's quota; 0 disables
+	CooldownMinutes  int              `json:"cooldown_minutes"`  // minimum time between repeat firings
+	Notifiers        []NotifierConfig `json:"notifiers"`
+}
+
+// NotifierConfig describes one way to deliver a fired alert.
+type NotifierConfig struct {
+	Type       string      `json:"type"` // "webhook" | "smtp" | "exec"
+	WebhookURL string      `json:"webhook_url,omitempty"`
+	SMTP       *SMTPConfig `json:"smtp,omitempty"`
+	Command    string      `json:"command,omitempty"` // shell command, receives rule, interface, direction, period and quota bytes as ALERT_* env vars
+}
+
+// SMTPConfig holds the mail server settings for the "smtp" notifier type.
+type SMTPConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+}
+
+// alertPayload is the JSON body sent to webhook notifiers and the data made
+// available to smtp/exec notifiers.
+type alertPayload struct {
+	Rule        string    `json:"rule"`
+	Hostname    string    `json:"hostname"`
+	Interface   string    `json:"interface"`
+	Direction   string    `json:"direction"`
+	PeriodBytes uint64    `json:"period_bytes"`
+	QuotaBytes  uint64    `json:"quota_bytes"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// alertState tracks the last time each rule fired, to enforce cooldowns.
+// Persisted to alerts_state.json alongside the traffic data file.
+type alertState map[string]time.Time
+
+const alertStateFile = "alerts_state.json"
+
+func loadOrCreateAlertState() (alertState, error) {
+	var state alertState
+	_, err := os.Stat(alertStateFile)
+	if os.IsNotExist(err) {
+		state = alertState{}
+		if err := saveAlertState(state); err != nil {
+			return state, err
+		}
+		return state, nil
+	}
+
+	file, err := os.Open(alertStateFile)
+	if err != nil {
+		return state, err
+	}
+	defer file.Close()
+
+	if err := json.NewDecoder(file).Decode(&state); err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
+func saveAlertState(state alertState) error {
+	file, err := os.Create(alertStateFile)
+	if err != nil {
+		return fmt.Errorf("error creating alert state file: %v", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(state)
+}
+
+// evaluateAlerts runs every threshold rule configured for ifaceConfig against
+// its current period totals, dispatching notifiers for any that just crossed
+// their threshold outside of their cooldown. Returns true if state changed.
+func evaluateAlerts(config *Config, store Store, state alertState, ifaceConfig InterfaceConfig) bool {
+	dirty := false
+	for _, rule := range config.Alerts {
+		if rule.Interface != ifaceConfig.Name || rule.Direction == "reset" {
+			continue
+		}
+
+		period, err := store.Get(ifaceConfig.Name)
+		if err != nil {
+			log.Printf("Error reading traffic store for alert %s: %v", rule.Name, err)
+			continue
+		}
+
+		var current uint64
+		switch rule.Direction {
+		case "sent":
+			current = period.TotalBytesSent
+		case "recv":
+			current = period.TotalBytesRecv
+		default:
+			current = period.TotalBytesSent + period.TotalBytesRecv
+		}
+
+		threshold := rule.ThresholdBytes
+		if rule.ThresholdPercent > 0 && ifaceConfig.QuotaBytes > 0 {
+			percentThreshold := uint64(float64(ifaceConfig.QuotaBytes) * rule.ThresholdPercent / 100)
+			if threshold == 0 || percentThreshold < threshold {
+				threshold = percentThreshold
+			}
+		}
+		if threshold == 0 || current < threshold {
+			// Back under threshold: clear any fired state so the next time
+			// it's crossed fires again, even with no configured cooldown.
+			if _, ok := state[rule.Name]; ok {
+				delete(state, rule.Name)
+				dirty = true
+			}
+			continue
+		}
+
+		if lastFired, ok := state[rule.Name]; ok {
+			if rule.CooldownMinutes <= 0 {
+				// No configured cooldown: fire once per continuous breach
+				// instead of re-firing on every sampling tick.
+				continue
+			}
+			if time.Since(lastFired) < time.Duration(rule.CooldownMinutes)*time.Minute {
+				continue
+			}
+		}
+
+		fireAlert(rule, alertPayload{
+			Rule:        rule.Name,
+			Hostname:    hostname(),
+			Interface:   ifaceConfig.Name,
+			Direction:   rule.Direction,
+			PeriodBytes: current,
+			QuotaBytes:  ifaceConfig.QuotaBytes,
+			Timestamp:   time.Now(),
+		})
+		state[rule.Name] = time.Now()
+		dirty = true
+	}
+	return dirty
+}
+
+// fireResetAlerts notifies any "reset" rules configured for ifaceConfig, used
+// when its monthly quota counter rolls over.
+func fireResetAlerts(config *Config, ifaceConfig InterfaceConfig) {
+	for _, rule := range config.Alerts {
+		if rule.Interface != ifaceConfig.Name || rule.Direction != "reset" {
+			continue
+		}
+		fireAlert(rule, alertPayload{
+			Rule:       rule.Name,
+			Hostname:   hostname(),
+			Interface:  ifaceConfig.Name,
+			Direction:  "reset",
+			QuotaBytes: ifaceConfig.QuotaBytes,
+			Timestamp:  time.Now(),
+		})
+	}
+}
+
+func fireAlert(rule AlertRule, payload alertPayload) {
+	for _, notifier := range rule.Notifiers {
+		var err error
+		switch notifier.Type {
+		case "webhook":
+			err = sendWebhook(notifier.WebhookURL, payload)
+		case "smtp":
+			err = sendSMTP(notifier.SMTP, rule, payload)
+		case "exec":
+			err = runNotifierCommand(notifier.Command, payload)
+		default:
+			err = fmt.Errorf("unknown notifier type %q", notifier.Type)
+		}
+		if err != nil {
+			log.Printf("Error delivering alert %s via %s notifier: %v", rule.Name, notifier.Type, err)
+		}
+	}
+}
+
+func sendWebhook(url string, payload alertPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func sendSMTP(cfg *SMTPConfig, rule AlertRule, payload alertPayload) error {
+	if cfg == nil {
+		return fmt.Errorf("alert %s has no smtp config", rule.Name)
+	}
+	subject := fmt.Sprintf("TrafficData alert: %s", rule.Name)
+	body := fmt.Sprintf("Interface: %s\nDirection: %s\nPeriod bytes: %d\nQuota bytes: %d\nTime: %s\n",
+		payload.Interface, payload.Direction, payload.PeriodBytes, payload.QuotaBytes, payload.Timestamp.Format(time.RFC3339))
+	msg := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", cfg.From, joinAddrs(cfg.To), subject, body))
+
+	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return smtp.SendMail(addr, auth, cfg.From, cfg.To, msg)
+}
+
+func runNotifierCommand(command string, payload alertPayload) error {
+	if command == "" {
+		return fmt.Errorf("exec notifier has no command")
+	}
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("ALERT_RULE=%s", payload.Rule),
+		fmt.Sprintf("ALERT_INTERFACE=%s", payload.Interface),
+		fmt.Sprintf("ALERT_DIRECTION=%s", payload.Direction),
+		fmt.Sprintf("ALERT_PERIOD_BYTES=%d", payload.PeriodBytes),
+		fmt.Sprintf("ALERT_QUOTA_BYTES=%d", payload.QuotaBytes),
+	)
+	return cmd.Run()
+}
+
+func joinAddrs(addrs []string) string {
+	joined := ""
+	for i, addr := range addrs {
+		if i > 0 {
+			joined += ", "
+		}
+		joined += addr
+	}
+	return joined
+}
+
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return name
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"log"
+	"time"
+)
+
+// rollupBucket is the fixed granularity persisted rollups are aggregated to.
+const rollupBucket = time.Hour
+
+// runRetentionCompactor periodically enforces the store's two-tier
+// retention policy: raw samples older than config.RetentionDays are rolled
+// up into hourly points and pruned, while those rollups themselves are kept
+// for config.RollupRetentionDays before being pruned too. It runs for the
+// lifetime of the process and never returns.
+func runRetentionCompactor(store Store, config *Config) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+
+		if config.RetentionDays > 0 {
+			cutoff := now.AddDate(0, 0, -config.RetentionDays)
+			for _, ifaceConfig := range config.Interfaces {
+				if err := rollupExpiringSamples(store, ifaceConfig.Name, cutoff); err != nil {
+					log.Printf("Error rolling up expiring samples for %s: %v", ifaceConfig.Name, err)
+				}
+			}
+			if err := store.Prune(cutoff); err != nil {
+				log.Printf("Error pruning traffic store: %v", err)
+			}
+		}
+
+		if config.RollupRetentionDays > 0 {
+			cutoff := now.AddDate(0, 0, -config.RollupRetentionDays)
+			if err := store.PruneRollups(cutoff); err != nil {
+				log.Printf("Error pruning rollup store: %v", err)
+			}
+		}
+	}
+}
+
+// rollupExpiringSamples buckets iface's raw samples older than cutoff into
+// hourly points and persists them, so coarse history survives the raw
+// samples being pruned out from under it.
+func rollupExpiringSamples(store Store, iface string, cutoff time.Time) error {
+	samples, err := store.Range(iface, time.Unix(0, 0), cutoff)
+	if err != nil {
+		return err
+	}
+
+	for _, rollup := range bucketSamples(samples, rollupBucket) {
+		if err := store.PutRollup(iface, rollup.Timestamp.Truncate(rollupBucket), rollup.Sent, rollup.Recv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/luiguangguan/TrafficData.go/pb"
+)
+
+// trafficServer implements pb.TrafficServiceServer on top of the same Store
+// and Config the HTTP handlers use, so both APIs always agree.
+type trafficServer struct {
+	pb.UnimplementedTrafficServiceServer
+	store  Store
+	config *Config
+}
+
+// runGRPCServer listens on config.GRPCPort and serves TrafficService until
+// the process exits. Meant to be run in its own goroutine alongside the HTTP
+// server started in main.
+func runGRPCServer(store Store, config *Config) {
+	addr := fmt.Sprintf(":%d", config.GRPCPort)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("Error listening for gRPC on %s: %v", addr, err)
+	}
+
+	server := grpc.NewServer()
+	pb.RegisterTrafficServiceServer(server, &trafficServer{store: store, config: config})
+	log.Printf("gRPC server listening on %s", addr)
+	if err := server.Serve(lis); err != nil {
+		log.Fatalf("Error serving gRPC: %v", err)
+	}
+}
+
+func (s *trafficServer) defaultIfname(ifname string) string {
+	if ifname == "" && len(s.config.Interfaces) > 0 {
+		return s.config.Interfaces[0].Name
+	}
+	return ifname
+}
+
+func (s *trafficServer) GetTotal(ctx context.Context, req *pb.GetTotalRequest) (*pb.TotalReply, error) {
+	ifname := s.defaultIfname(req.GetIfname())
+
+	period, err := s.store.Get(ifname)
+	if err != nil {
+		return nil, fmt.Errorf("error reading traffic store: %v", err)
+	}
+
+	var currentSent, currentRecv int64
+	_sent, _recv, err := getInterfaceTraffic(ifname)
+	if err != nil {
+		currentSent, currentRecv = -1, -1
+		log.Printf("Error getting current traffic: %v", err)
+	} else {
+		currentSent, currentRecv = int64(_sent), int64(_recv)
+	}
+
+	return &pb.TotalReply{
+		TotalBytesSent:       period.TotalBytesSent,
+		TotalBytesReceived:   period.TotalBytesRecv,
+		CurrentBytesSent:     currentSent,
+		CurrentBytesReceived: currentRecv,
+	}, nil
+}
+
+// StreamLive pushes a LiveSample every interval_seconds, computed from the
+// delta between consecutive net.IOCounters readings rather than the
+// reboot-resettable cumulative counters themselves.
+func (s *trafficServer) StreamLive(req *pb.StreamLiveRequest, stream pb.TrafficService_StreamLiveServer) error {
+	ifname := s.defaultIfname(req.GetIfname())
+	interval := time.Duration(req.GetIntervalSeconds()) * time.Second
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	lastSent, lastRecv, err := getInterfaceTraffic(ifname)
+	if err != nil {
+		return fmt.Errorf("error reading interface %q: %v", ifname, err)
+	}
+	lastTime := time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case now := <-ticker.C:
+			sent, recv, err := getInterfaceTraffic(ifname)
+			if err != nil {
+				log.Printf("Error reading interface %q for live stream: %v", ifname, err)
+				continue
+			}
+
+			elapsed := now.Sub(lastTime).Seconds()
+			if elapsed <= 0 {
+				elapsed = interval.Seconds()
+			}
+			bpsUp := float64(deltaSince(lastSent, sent)) / elapsed
+			bpsDown := float64(deltaSince(lastRecv, recv)) / elapsed
+			lastSent, lastRecv, lastTime = sent, recv, now
+
+			err = stream.Send(&pb.LiveSample{
+				Ifname:  ifname,
+				BpsUp:   bpsUp,
+				BpsDown: bpsDown,
+				Ts:      timestamppb.New(now),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *trafficServer) GetHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.HistoryReply, error) {
+	ifname := s.defaultIfname(req.GetIfname())
+
+	from := time.Unix(0, 0)
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime()
+	}
+	to := time.Now()
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime()
+	}
+
+	samples, err := s.store.Range(ifname, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error reading history range: %v", err)
+	}
+
+	// Mirror handleHistory's merge of raw samples with their hourly rollups,
+	// so GetHistory answers queries past the raw retention window the same
+	// way GET /history does.
+	rollups, err := s.store.RangeRollups(ifname, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rollup range: %v", err)
+	}
+	samples = mergeSamples(samples, rollups)
+
+	if req.GetBucket() != "" {
+		if bucket, err := time.ParseDuration(req.GetBucket()); err == nil {
+			samples = bucketSamples(samples, bucket)
+		}
+	}
+
+	reply := &pb.HistoryReply{Samples: make([]*pb.HistorySample, 0, len(samples))}
+	for _, sample := range samples {
+		reply.Samples = append(reply.Samples, &pb.HistorySample{
+			Ts:   timestamppb.New(sample.Timestamp),
+			Sent: sample.Sent,
+			Recv: sample.Recv,
+		})
+	}
+	return reply, nil
+}
+
+// ResetNow forces an immediate rollover of ifname via forceResetInterface,
+// the same path checkAndResetTraffic's periodic check uses, so a forced
+// reset also updates LastResetDate and fires any configured "reset" alert.
+func (s *trafficServer) ResetNow(ctx context.Context, req *pb.ResetNowRequest) (*pb.ResetNowReply, error) {
+	ifname := s.defaultIfname(req.GetIfname())
+	if err := forceResetInterface(s.config, s.store, ifname); err != nil {
+		return nil, fmt.Errorf("error resetting traffic store for %s: %v", ifname, err)
+	}
+	return &pb.ResetNowReply{Ok: true}, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// handleHistory answers /history?if=&from=&to=&bucket= queries against the
+// configured Store, returning raw or bucketed samples as JSON. from/to use
+// RFC3339 timestamps; bucket is a Go duration string such as "1h" or "15m".
+// if defaults to the first configured interface when omitted.
+func handleHistory(store Store, config *Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ifname := r.URL.Query().Get("if")
+		if ifname == "" && len(config.Interfaces) > 0 {
+			ifname = config.Interfaces[0].Name
+		}
+
+		from := time.Unix(0, 0)
+		to := time.Now()
+
+		if v := r.URL.Query().Get("from"); v != "" {
+			if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+				from = parsed
+			}
+		}
+		if v := r.URL.Query().Get("to"); v != "" {
+			if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+				to = parsed
+			}
+		}
+
+		samples, err := store.Range(ifname, from, to)
+		if err != nil {
+			http.Error(w, "Failed to read history", http.StatusInternalServerError)
+			log.Printf("Error reading history range: %v", err)
+			return
+		}
+
+		// Raw samples are pruned past the retention window, but their hourly
+		// rollups live on; merge both so /history still answers queries that
+		// reach further back than raw retention allows.
+		rollups, err := store.RangeRollups(ifname, from, to)
+		if err != nil {
+			http.Error(w, "Failed to read rollup history", http.StatusInternalServerError)
+			log.Printf("Error reading rollup history range: %v", err)
+			return
+		}
+		samples = mergeSamples(samples, rollups)
+
+		if v := r.URL.Query().Get("bucket"); v != "" {
+			if bucket, err := time.ParseDuration(v); err == nil {
+				samples = bucketSamples(samples, bucket)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(samples); err != nil {
+			http.Error(w, "Failed to encode JSON response", http.StatusInternalServerError)
+			log.Printf("Error encoding history response: %v", err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// interfaceStatus is the /interfaces response shape for a single tracked NIC.
+type interfaceStatus struct {
+	Name         string  `json:"name"`
+	Label        string  `json:"label,omitempty"`
+	CurrentSent  uint64  `json:"current_bytes_sent"`
+	CurrentRecv  uint64  `json:"current_bytes_received"`
+	PeriodSent   uint64  `json:"period_bytes_sent"`
+	PeriodRecv   uint64  `json:"period_bytes_received"`
+	QuotaBytes   uint64  `json:"quota_bytes"`
+	UsagePercent float64 `json:"usage_percent"`
+}
+
+// handleInterfaces lists every tracked NIC along with its current usage,
+// quota, and percentage used — useful on multi-homed routers with separate
+// WAN/LAN accounting.
+func handleInterfaces(store Store, config *Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		statuses := make([]interfaceStatus, 0, len(config.Interfaces))
+
+		for _, ifaceConfig := range config.Interfaces {
+			period, err := store.Get(ifaceConfig.Name)
+			if err != nil {
+				http.Error(w, "Failed to read traffic store", http.StatusInternalServerError)
+				log.Printf("Error reading traffic store for %s: %v", ifaceConfig.Name, err)
+				return
+			}
+
+			sent, recv, err := getInterfaceTraffic(ifaceConfig.Name)
+			if err != nil {
+				log.Printf("Error getting current traffic for %s: %v", ifaceConfig.Name, err)
+			}
+
+			periodTotal := period.TotalBytesSent + period.TotalBytesRecv
+			var usagePercent float64
+			if ifaceConfig.QuotaBytes > 0 {
+				usagePercent = float64(periodTotal) / float64(ifaceConfig.QuotaBytes) * 100
+			}
+
+			statuses = append(statuses, interfaceStatus{
+				Name:         ifaceConfig.Name,
+				Label:        ifaceConfig.Label,
+				CurrentSent:  sent,
+				CurrentRecv:  recv,
+				PeriodSent:   period.TotalBytesSent,
+				PeriodRecv:   period.TotalBytesRecv,
+				QuotaBytes:   ifaceConfig.QuotaBytes,
+				UsagePercent: usagePercent,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(statuses); err != nil {
+			http.Error(w, "Failed to encode JSON response", http.StatusInternalServerError)
+			log.Printf("Error encoding interfaces response: %v", err)
+		}
+	}
+}
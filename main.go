@@ -3,12 +3,10 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
-	"os/exec"
-	"runtime"
-	"strings"
 	"time"
 
 	"github.com/shirou/gopsutil/net"
@@ -18,11 +16,27 @@ import (
 
 // Config represents the configuration structure
 type Config struct {
+	DataFile            string            `json:"data_file"`             // 保存流量信息的文件路径
+	Port                int               `json:"port"`                  // Web 服务器监听端口
+	Interfaces          []InterfaceConfig `json:"interfaces"`            // 跟踪的网卡列表，各自独立配额与清零日
+	MetricsEnabled      bool              `json:"metrics_enabled"`       // 是否启用 /metrics 接口
+	MetricsPath         string            `json:"metrics_path"`          // /metrics 接口路径
+	StorageDriver       string            `json:"storage_driver"`        // 存储后端: json | buntdb | sqlite
+	RetentionDays       int               `json:"retention_days"`        // 原始采样点保留天数，0 表示永久保留
+	RollupRetentionDays int               `json:"rollup_retention_days"` // 小时级汇总保留天数，0 表示永久保留
+	Alerts              []AlertRule       `json:"alerts"`                // 配额告警规则
+	GRPCPort            int               `json:"grpc_port"`             // gRPC 服务器监听端口，0 表示不启用
+}
+
+// InterfaceConfig describes one tracked network interface: its monthly quota,
+// its own reset day (useful when WAN and LAN bill on different cycles), and
+// the last date it was reset.
+type InterfaceConfig struct {
+	Name          string `json:"name"`
+	Label         string `json:"label,omitempty"` // 人类可读的名称，例如 "WAN"
+	QuotaBytes    uint64 `json:"quota_bytes"`     // 每月配额，0 表示不限制
 	ResetDay      int    `json:"reset_day"`       // 每月几号清零
-	DataFile      string `json:"data_file"`       // 保存流量信息的文件路径
 	LastResetDate string `json:"last_reset_date"` // 最后一次清零的日期
-	Port          int    `json:"port"`            // Web 服务器监听端口
-	IfName        string `json:"ifName"`
 }
 
 // TrafficData represents the traffic data structure
@@ -31,10 +45,8 @@ type TrafficData struct {
 	TotalBytesRecv uint64 `json:"total_bytes_recv"`
 }
 
-var recTotal uint64 = 0
-var senTotal uint64 = 0
-
-// TrafficRecords represents the traffic records map with boot times as keys
+// TrafficRecords represents the traffic records map, keyed internally by the
+// storage backend (see jsonStore for the "sample::<ifname>::<timestamp>" scheme)
 type TrafficRecords map[string]TrafficData
 
 // Load or create configuration from the config file
@@ -44,11 +56,17 @@ func loadOrCreateConfig(configFile string) (Config, error) {
 	if os.IsNotExist(err) {
 		// If the file doesn't exist, create it with default values
 		config = Config{
-			ResetDay:      1,                   // 默认每月1号清零
-			DataFile:      "traffic_data.json", // 默认数据文件名
-			LastResetDate: "",                  // 最后一次清零日期初始为空
-			Port:          28080,               // 默认 Web 服务器监听端口
-			IfName:        "eth0",              // 统计的网卡名称
+			DataFile: "traffic_data.json", // 默认数据文件名
+			Port:     28080,               // 默认 Web 服务器监听端口
+			Interfaces: []InterfaceConfig{
+				{Name: "eth0", ResetDay: 1}, // 默认跟踪 eth0，每月1号清零
+			},
+			MetricsEnabled:      true,       // 默认开启 Prometheus 指标
+			MetricsPath:         "/metrics", // 默认指标路径
+			StorageDriver:       "json",     // 默认使用 JSON 文件存储
+			RetentionDays:       7,          // 默认原始采样点保留 7 天
+			RollupRetentionDays: 90,         // 默认小时级汇总保留 90 天
+			GRPCPort:            0,          // 默认不启用 gRPC 服务器
 		}
 		err = saveConfig(configFile, config)
 		if err != nil {
@@ -63,15 +81,56 @@ func loadOrCreateConfig(configFile string) (Config, error) {
 		}
 		defer file.Close()
 
-		decoder := json.NewDecoder(transform.NewReader(file, unicode.UTF8.NewDecoder()))
-		err = decoder.Decode(&config)
+		raw, err := io.ReadAll(transform.NewReader(file, unicode.UTF8.NewDecoder()))
 		if err != nil {
 			return config, err
 		}
+		if err := json.Unmarshal(raw, &config); err != nil {
+			return config, err
+		}
+
+		if migrateLegacyConfigFields(&config, raw) {
+			if err := saveConfig(configFile, config); err != nil {
+				return config, err
+			}
+		}
 	}
 	return config, nil
 }
 
+// legacyConfigFields captures the flat, single-interface config.json keys
+// that predate per-interface tracking (see InterfaceConfig). json.Decode
+// silently zero-fills Config.Interfaces for a config file written in this
+// shape, so it's parsed separately to detect and migrate it.
+type legacyConfigFields struct {
+	IfName        string `json:"ifName"`
+	ResetDay      int    `json:"reset_day"`
+	LastResetDate string `json:"last_reset_date"`
+}
+
+// migrateLegacyConfigFields synthesizes a single InterfaceConfig from raw's
+// legacy flat fields if config came back with no tracked interfaces at all,
+// so an install upgrading config.json in place doesn't silently stop
+// sampling. Returns true if it changed config.
+func migrateLegacyConfigFields(config *Config, raw []byte) bool {
+	if len(config.Interfaces) > 0 {
+		return false
+	}
+
+	var legacy legacyConfigFields
+	if err := json.Unmarshal(raw, &legacy); err != nil || legacy.IfName == "" {
+		return false
+	}
+
+	config.Interfaces = []InterfaceConfig{{
+		Name:          legacy.IfName,
+		ResetDay:      legacy.ResetDay,
+		LastResetDate: legacy.LastResetDate,
+	}}
+	log.Printf("Migrated legacy config field ifName=%q into interfaces.", legacy.IfName)
+	return true
+}
+
 // Save the current configuration to the config file
 func saveConfig(configFile string, config Config) error {
 	file, err := os.Create(configFile)
@@ -155,141 +214,112 @@ func saveTrafficData(dataFile string, records TrafficRecords) error {
 	return nil
 }
 
-// Get current traffic for all interfaces
-func getCurrentTraffic(ifname *string) (uint64, uint64, error) {
+// getInterfaceTraffic returns the cumulative bytes sent/received by a single
+// named interface since boot.
+func getInterfaceTraffic(ifname string) (uint64, uint64, error) {
 	interfaces, err := net.IOCounters(true)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	var totalSent, totalRecv uint64
 	for _, iface := range interfaces {
-		if ifname != nil && *ifname != "" {
-			if *ifname == iface.Name {
-				totalSent += iface.BytesSent
-				totalRecv += iface.BytesRecv
-				// println("name:%s", iface.Name)
-				// println("Up:%f", iface.BytesSent)
-				// println("Down:%f", iface.BytesRecv)
-			}
-		} else {
-			totalSent += iface.BytesSent
-			totalRecv += iface.BytesRecv
-			// println("name:%s", iface.Name)
-			// println("Up:%f", iface.BytesSent)
-			// println("Down:%f", iface.BytesRecv)
+		if iface.Name == ifname {
+			return iface.BytesSent, iface.BytesRecv, nil
 		}
-
 	}
 
-	senTotal = totalSent
-	recTotal = totalRecv
-
-	return totalSent, totalRecv, nil
+	return 0, 0, fmt.Errorf("interface %q not found", ifname)
 }
 
-// GetBootTime retrieves the system boot time as a string
-func GetBootTime() (string, error) {
-	var bootTimeStr string
+// resetInterface rolls over a single interface: it zeroes its stored totals,
+// records the rollover date, and fires any configured "reset" alert rule.
+// Shared by checkAndResetTraffic's periodic check and forceResetInterface's
+// on-demand reset (gRPC's ResetNow) so both paths stay in sync.
+func resetInterface(config *Config, store Store, ifaceConfig *InterfaceConfig, now time.Time) error {
+	if err := store.Reset(ifaceConfig.Name); err != nil {
+		return fmt.Errorf("error resetting traffic store for %s: %v", ifaceConfig.Name, err)
+	}
+	ifaceConfig.LastResetDate = now.Format("2006-01-02")
+	fireResetAlerts(config, *ifaceConfig)
+	log.Printf("Traffic data has been reset for interface %s.", ifaceConfig.Name)
+	return nil
+}
 
-	if isWindows() {
-		// Use PowerShell command to get system boot time on Windows
-		cmd := exec.Command("powershell", "-Command", "(Get-CimInstance -Class Win32_OperatingSystem).LastBootUpTime")
-		output, err := cmd.Output()
-		if err != nil {
-			return "", fmt.Errorf("error executing PowerShell command: %v", err)
+// forceResetInterface performs an immediate, out-of-cycle reset of ifname,
+// used by gRPC's ResetNow so a forced reset goes through the same rollover
+// path as the periodic check instead of only clearing the store.
+func forceResetInterface(config *Config, store Store, ifname string) error {
+	for i := range config.Interfaces {
+		if config.Interfaces[i].Name != ifname {
+			continue
 		}
-		bootTimeStr = strings.TrimSpace(string(output))
-	} else {
-		// Use uptime command to get system boot time on Linux
-		cmd := exec.Command("uptime", "-s")
-		output, err := cmd.Output()
-		if err != nil {
-			return "", fmt.Errorf("error executing uptime command: %v", err)
+		if err := resetInterface(config, store, &config.Interfaces[i], time.Now()); err != nil {
+			return err
 		}
-		bootTimeStr = strings.TrimSpace(string(output))
+		return saveConfig("config.json", *config)
 	}
 
-	return bootTimeStr, nil
-}
-
-// isWindows checks if the operating system is Windows
-func isWindows() bool {
-	return runtime.GOOS == "windows"
+	// ifname isn't a configured interface (e.g. stale or manually-specified
+	// name): still clear the store, but there's no InterfaceConfig to record
+	// a reset date against or fire alerts for.
+	if err := store.Reset(ifname); err != nil {
+		return fmt.Errorf("error resetting traffic store for %s: %v", ifname, err)
+	}
+	log.Printf("Traffic data has been reset for interface %s.", ifname)
+	return nil
 }
 
-// Check if today is the reset day and if it's time to reset the traffic
-func checkAndResetTraffic(config *Config, records *TrafficRecords) error {
-	// log.Println("reset reset reset reset reset reset???")
-
+// checkAndResetTraffic checks each tracked interface against its own reset
+// day and, once a day, rolls over any interface that has reached it
+func checkAndResetTraffic(config *Config, store Store) error {
 	now := time.Now()
-	resetDate := time.Date(now.Year(), now.Month(), config.ResetDay, 0, 0, 0, 0, time.UTC)
-
-	// Format the current date as a string in the format "YYYY-MM-DD"
-	currentDateStr := now.Format("2006-01-02")
-
 	layout := "2006-01-02"
-	lastRestday, _ := time.Parse(layout, config.LastResetDate)
-
-	// Check if today is the reset day and if the reset has not been done today
-	if now.After(resetDate) && resetDate.After(lastRestday) {
-		// Clear all traffic records
-		for key := range *records {
-			delete(*records, key)
-		}
 
-		// log.Println("reset reset reset reset reset reset!!!!")
+	dirty := false
+	for i := range config.Interfaces {
+		ifaceConfig := &config.Interfaces[i]
+		resetDate := time.Date(now.Year(), now.Month(), ifaceConfig.ResetDay, 0, 0, 0, 0, time.UTC)
+		lastRestday, _ := time.Parse(layout, ifaceConfig.LastResetDate)
 
-		// Update the last reset date in the config
-		config.LastResetDate = currentDateStr
-		err := saveConfig("config.json", *config)
-		if err != nil {
-			return fmt.Errorf("error saving config: %v", err)
-		}
-
-		if recTotal > 0 || senTotal > 0 {
-			// Get a copy of the TrafficData for the current boot time
-			data, exists := (*records)["resetSum"]
-			if !exists {
-				data = TrafficData{}
+		// Check if today is the reset day and if the reset has not been done today
+		if now.After(resetDate) && resetDate.After(lastRestday) {
+			if err := resetInterface(config, store, ifaceConfig, now); err != nil {
+				return err
 			}
-			data.TotalBytesSent = senTotal
-			data.TotalBytesRecv = recTotal
-			// Update the map with the modified data
-			(*records)["resetSum"] = data
+			dirty = true
 		}
+	}
 
-		// Save the updated empty records
-		err = saveTrafficData(config.DataFile, *records)
-		if err != nil {
-			return fmt.Errorf("error saving traffic data: %v", err)
+	if dirty {
+		if err := saveConfig("config.json", *config); err != nil {
+			return fmt.Errorf("error saving config: %v", err)
 		}
-
-		log.Println("Traffic data has been reset.")
 	}
 
 	return nil
 }
 
-// Web server to handle traffic queries and return data in JSON format
-func handleGetTotalTraffic(records *TrafficRecords, ifname *string) http.HandlerFunc {
+// Web server to handle traffic queries and return data in JSON format. The
+// optional ?if= query parameter selects a tracked interface; it defaults to
+// the first configured interface when omitted.
+func handleGetTotalTraffic(store Store, config *Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var totalSent, totalRecv, resetSent, resetRecv uint64
-		for key, data := range *records {
-			if key == "resetSum" {
-				resetSent = data.TotalBytesSent
-				resetRecv = data.TotalBytesRecv
-			} else {
-				totalSent += data.TotalBytesSent
-				totalRecv += data.TotalBytesRecv
-			}
+		ifname := r.URL.Query().Get("if")
+		if ifname == "" && len(config.Interfaces) > 0 {
+			ifname = config.Interfaces[0].Name
+		}
+
+		period, err := store.Get(ifname)
+		if err != nil {
+			http.Error(w, "Failed to read traffic store", http.StatusInternalServerError)
+			log.Printf("Error reading traffic store: %v", err)
+			return
 		}
-		totalSent -= resetSent
-		totalRecv -= resetRecv
+		totalSent, totalRecv := period.TotalBytesSent, period.TotalBytesRecv
 
 		// Get current traffic
 		var sent, recv int64
-		_sent, _recv, err := getCurrentTraffic(ifname)
+		_sent, _recv, err := getInterfaceTraffic(ifname)
 		if err != nil {
 			sent = -1
 			recv = -1
@@ -301,9 +331,9 @@ func handleGetTotalTraffic(records *TrafficRecords, ifname *string) http.Handler
 
 		// Create a response map
 		response := map[string]float64{
-			"total_bytes_sent_mb":       float64(totalRecv) / 1024 / 1024,
+			"total_bytes_sent_mb":       float64(totalSent) / 1024 / 1024,
 			"total_bytes_received_mb":   float64(totalRecv) / 1024 / 1024,
-			"total_bytes_sent":          float64(totalRecv),
+			"total_bytes_sent":          float64(totalSent),
 			"total_bytes_received":      float64(totalRecv),
 			"current_bytes_sent_mb":     float64(sent) / 1024 / 1024,
 			"current_bytes_received_mb": float64(recv) / 1024 / 1024,
@@ -330,63 +360,92 @@ func main() {
 	if err != nil {
 		log.Fatalf("Error loading or creating config: %v", err)
 	}
+	if len(config.Interfaces) == 0 {
+		log.Fatalf("No interfaces configured in config.json; add at least one to the \"interfaces\" list.")
+	}
 
-	// Load or create saved traffic data
-	records, err := loadOrCreateTrafficData(config.DataFile)
+	// Open the configured storage backend, migrating the legacy JSON file
+	// into it on first run if a different driver was selected. The first run
+	// is the one on which the driver's store file does not exist yet.
+	firstRun := false
+	if config.StorageDriver != "" && config.StorageDriver != "json" {
+		_, statErr := os.Stat(storeFilePath(config.DataFile, config.StorageDriver))
+		firstRun = os.IsNotExist(statErr)
+	}
+	store, err := NewStore(&config)
 	if err != nil {
-		log.Fatalf("Error loading or creating traffic data: %v", err)
+		log.Fatalf("Error opening traffic store: %v", err)
+	}
+	defer store.Close()
+	if firstRun && len(config.Interfaces) > 0 {
+		if err := migrateLegacyJSON(config.DataFile, store, config.Interfaces[0].Name); err != nil {
+			log.Printf("Error migrating legacy traffic data: %v", err)
+		}
 	}
 
 	// Start the web server
-	http.HandleFunc("/total", handleGetTotalTraffic(&records, &config.IfName))
+	http.HandleFunc("/total", handleGetTotalTraffic(store, &config))
+	http.HandleFunc("/history", handleHistory(store, &config))
+	http.HandleFunc("/interfaces", handleInterfaces(store, &config))
+	if config.MetricsEnabled {
+		metricsPath := config.MetricsPath
+		if metricsPath == "" {
+			metricsPath = "/metrics"
+		}
+		http.HandleFunc(metricsPath, handleMetrics(store, &config))
+	}
 	addr := fmt.Sprintf(":%d", config.Port)
 	go func() {
 		log.Fatal(http.ListenAndServe(addr, nil))
 	}()
 
-	for {
-		// Get current traffic
-		sent, recv, err := getCurrentTraffic(&config.IfName)
-		if err != nil {
-			log.Printf("Error getting current traffic: %v", err)
-			continue
-		}
+	// Start the gRPC server alongside the REST API for consumers that want a
+	// typed API or the StreamLive live-rate feed
+	if config.GRPCPort > 0 {
+		go runGRPCServer(store, &config)
+	}
 
-		// Get the system boot time as the key
-		bootTime, err := GetBootTime()
-		if err != nil {
-			log.Printf("Error getting boot time: %v", err)
-			continue
-		}
+	// Periodically roll up and prune samples per the retention policy
+	if config.RetentionDays > 0 || config.RollupRetentionDays > 0 {
+		go runRetentionCompactor(store, &config)
+	}
 
-		// Get a copy of the TrafficData for the current boot time
-		data, exists := records[bootTime]
-		if !exists {
-			data = TrafficData{}
-		}
-		data.TotalBytesSent = sent
-		data.TotalBytesRecv = recv
-		// Update the map with the modified data
-		records[bootTime] = data
+	// Load the persisted alert cooldown state
+	alertCooldowns, err := loadOrCreateAlertState()
+	if err != nil {
+		log.Fatalf("Error loading alert state: %v", err)
+	}
 
-		// log.Printf("\ntime:%s", bootTime)
+	for {
+		now := time.Now()
+		alertsDirty := false
+		// Sample every tracked interface independently
+		for _, ifaceConfig := range config.Interfaces {
+			sent, recv, err := getInterfaceTraffic(ifaceConfig.Name)
+			if err != nil {
+				log.Printf("Error getting current traffic for %s: %v", ifaceConfig.Name, err)
+				continue
+			}
 
-		// Save the updated traffic data
-		err = saveTrafficData(config.DataFile, records)
-		if err != nil {
-			log.Printf("Error saving traffic data: %v", err)
+			if err := store.PutSample(ifaceConfig.Name, now, sent, recv); err != nil {
+				log.Printf("Error saving traffic data for %s: %v", ifaceConfig.Name, err)
+			}
+
+			if evaluateAlerts(&config, store, alertCooldowns, ifaceConfig) {
+				alertsDirty = true
+			}
+		}
+		if alertsDirty {
+			if err := saveAlertState(alertCooldowns); err != nil {
+				log.Printf("Error saving alert state: %v", err)
+			}
 		}
 
 		// Check and reset traffic data if necessary
-		err = checkAndResetTraffic(&config, &records)
-		if err != nil {
+		if err := checkAndResetTraffic(&config, store); err != nil {
 			log.Fatalf("Error checking and resetting traffic: %v", err)
 		}
 
-		// Print the traffic data
-		// fmt.Printf("Total Bytes Sent: %.2f MB\nTotal Bytes Received: %.2f MB\n", float64(data.TotalBytesSent/1024/1024), float64(data.TotalBytesRecv/1024/1024))
-		// fmt.Printf("Current Bytes Sent: %.2f MB\nCurrent Bytes Received: %.2f MB\n", float64(sent/1024/1024), float64(recv/1024/1024))
-
 		// Wait for two seconds
 		time.Sleep(3 * time.Second)
 	}
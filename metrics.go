@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/host"
+	"github.com/shirou/gopsutil/load"
+	"github.com/shirou/gopsutil/mem"
+	"github.com/shirou/gopsutil/net"
+)
+
+// handleMetrics exposes traffic and system stats in Prometheus text exposition format
+func handleMetrics(store Store, config *Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+		interfaces, err := net.IOCounters(true)
+		if err != nil {
+			http.Error(w, "Failed to read interface counters", http.StatusInternalServerError)
+			return
+		}
+
+		fmt.Fprintln(w, "# HELP trafficdata_interface_bytes_total Cumulative bytes per interface since boot")
+		fmt.Fprintln(w, "# TYPE trafficdata_interface_bytes_total counter")
+		fmt.Fprintln(w, "# HELP trafficdata_interface_packets_total Cumulative packets per interface since boot")
+		fmt.Fprintln(w, "# TYPE trafficdata_interface_packets_total counter")
+		fmt.Fprintln(w, "# HELP trafficdata_interface_errors_total Cumulative errors per interface since boot")
+		fmt.Fprintln(w, "# TYPE trafficdata_interface_errors_total counter")
+		fmt.Fprintln(w, "# HELP trafficdata_interface_drops_total Cumulative dropped packets per interface since boot")
+		fmt.Fprintln(w, "# TYPE trafficdata_interface_drops_total counter")
+		tracked := make(map[string]bool, len(config.Interfaces))
+		for _, ifaceConfig := range config.Interfaces {
+			tracked[ifaceConfig.Name] = true
+		}
+
+		for _, iface := range interfaces {
+			if len(tracked) > 0 && !tracked[iface.Name] {
+				continue
+			}
+			fmt.Fprintf(w, "trafficdata_interface_bytes_total{interface=%q,direction=\"sent\"} %d\n", iface.Name, iface.BytesSent)
+			fmt.Fprintf(w, "trafficdata_interface_bytes_total{interface=%q,direction=\"recv\"} %d\n", iface.Name, iface.BytesRecv)
+			fmt.Fprintf(w, "trafficdata_interface_packets_total{interface=%q,direction=\"sent\"} %d\n", iface.Name, iface.PacketsSent)
+			fmt.Fprintf(w, "trafficdata_interface_packets_total{interface=%q,direction=\"recv\"} %d\n", iface.Name, iface.PacketsRecv)
+			fmt.Fprintf(w, "trafficdata_interface_errors_total{interface=%q,direction=\"sent\"} %d\n", iface.Name, iface.Errout)
+			fmt.Fprintf(w, "trafficdata_interface_errors_total{interface=%q,direction=\"recv\"} %d\n", iface.Name, iface.Errin)
+			fmt.Fprintf(w, "trafficdata_interface_drops_total{interface=%q,direction=\"sent\"} %d\n", iface.Name, iface.Dropout)
+			fmt.Fprintf(w, "trafficdata_interface_drops_total{interface=%q,direction=\"recv\"} %d\n", iface.Name, iface.Dropin)
+		}
+
+		fmt.Fprintln(w, "# HELP trafficdata_period_bytes Bytes accumulated since the start of each interface's reset window")
+		fmt.Fprintln(w, "# TYPE trafficdata_period_bytes gauge")
+		for _, ifaceConfig := range config.Interfaces {
+			period, err := store.Get(ifaceConfig.Name)
+			if err != nil {
+				log.Printf("Error reading traffic store for %s: %v", ifaceConfig.Name, err)
+				continue
+			}
+			fmt.Fprintf(w, "trafficdata_period_bytes{interface=%q,direction=\"sent\"} %d\n", ifaceConfig.Name, period.TotalBytesSent)
+			fmt.Fprintf(w, "trafficdata_period_bytes{interface=%q,direction=\"recv\"} %d\n", ifaceConfig.Name, period.TotalBytesRecv)
+		}
+
+		loadAvg, err := load.Avg()
+		if err == nil {
+			fmt.Fprintln(w, "# HELP trafficdata_system_load Load average reported by the host")
+			fmt.Fprintln(w, "# TYPE trafficdata_system_load gauge")
+			fmt.Fprintf(w, "trafficdata_system_load{period=\"1m\"} %f\n", loadAvg.Load1)
+			fmt.Fprintf(w, "trafficdata_system_load{period=\"5m\"} %f\n", loadAvg.Load5)
+			fmt.Fprintf(w, "trafficdata_system_load{period=\"15m\"} %f\n", loadAvg.Load15)
+		}
+
+		if hostInfo, err := host.Info(); err == nil {
+			fmt.Fprintln(w, "# HELP trafficdata_system_uptime_seconds Seconds since the host last booted")
+			fmt.Fprintln(w, "# TYPE trafficdata_system_uptime_seconds counter")
+			fmt.Fprintf(w, "trafficdata_system_uptime_seconds %d\n", hostInfo.Uptime)
+		}
+
+		if vmem, err := mem.VirtualMemory(); err == nil {
+			fmt.Fprintln(w, "# HELP trafficdata_system_memory_bytes Virtual memory stats")
+			fmt.Fprintln(w, "# TYPE trafficdata_system_memory_bytes gauge")
+			fmt.Fprintf(w, "trafficdata_system_memory_bytes{state=\"total\"} %d\n", vmem.Total)
+			fmt.Fprintf(w, "trafficdata_system_memory_bytes{state=\"used\"} %d\n", vmem.Used)
+			fmt.Fprintf(w, "trafficdata_system_memory_bytes{state=\"available\"} %d\n", vmem.Available)
+		}
+
+		if cpuPercent, err := cpu.Percent(0, false); err == nil && len(cpuPercent) > 0 {
+			fmt.Fprintln(w, "# HELP trafficdata_system_cpu_percent CPU utilization percentage")
+			fmt.Fprintln(w, "# TYPE trafficdata_system_cpu_percent gauge")
+			fmt.Fprintf(w, "trafficdata_system_cpu_percent %f\n", cpuPercent[0])
+		}
+
+		fmt.Fprintln(w, "# HELP trafficdata_scrape_timestamp_seconds Unix time this scrape was generated")
+		fmt.Fprintln(w, "# TYPE trafficdata_scrape_timestamp_seconds gauge")
+		fmt.Fprintf(w, "trafficdata_scrape_timestamp_seconds %d\n", time.Now().Unix())
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+)
+
+// legacySample is one (interface, timestamp, raw reading) row recovered from
+// an older data file, before it's replayed into the new store in order.
+type legacySample struct {
+	iface string
+	ts    time.Time
+	data  TrafficData
+}
+
+// migrateLegacyJSON imports traffic samples from an existing JSON data file
+// into store, for users switching storage_driver away from "json". It is a
+// no-op if the legacy file doesn't exist. defaultIface is used for data files
+// written before per-interface tracking, which didn't record an interface
+// name. Samples are replayed in timestamp order per interface so the
+// destination store's delta accumulation (see jsonStore) computes correctly.
+func migrateLegacyJSON(legacyFile string, store Store, defaultIface string) error {
+	if _, err := os.Stat(legacyFile); os.IsNotExist(err) {
+		return nil
+	}
+
+	records, err := loadOrCreateTrafficData(legacyFile)
+	if err != nil {
+		return fmt.Errorf("error reading legacy data file %s: %v", legacyFile, err)
+	}
+
+	var legacy []legacySample
+	for key, data := range records {
+		iface, ts, ok := parseSampleKey(key)
+		if !ok {
+			// Pre-multi-interface data files keyed samples by boot time
+			// for a single, unnamed interface
+			parsedTs, err := time.Parse("2006-01-02 15:04:05", key)
+			if err != nil {
+				log.Printf("Skipping unrecognized legacy key %q in %s: not a boot-time timestamp", key, legacyFile)
+				continue
+			}
+			iface, ts = defaultIface, parsedTs
+		}
+		legacy = append(legacy, legacySample{iface: iface, ts: ts, data: data})
+	}
+
+	sort.Slice(legacy, func(i, j int) bool {
+		return legacy[i].ts.Before(legacy[j].ts)
+	})
+
+	imported := 0
+	for _, sample := range legacy {
+		if err := store.PutSample(sample.iface, sample.ts, sample.data.TotalBytesSent, sample.data.TotalBytesRecv); err != nil {
+			return fmt.Errorf("error importing sample for %s at %s: %v", sample.iface, sample.ts, err)
+		}
+		imported++
+	}
+
+	log.Printf("Migrated %d samples from %s into the new store.", imported, legacyFile)
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestNewStoreDriverSwitchPreservesLegacyFile exercises switching
+// storage_driver away from "json" with a pre-existing JSON data file still
+// at DataFile: NewStore must not touch that file, so migrateLegacyJSON can
+// still read it afterward.
+func TestNewStoreDriverSwitchPreservesLegacyFile(t *testing.T) {
+	for _, driver := range []string{"buntdb", "sqlite"} {
+		t.Run(driver, func(t *testing.T) {
+			dir := t.TempDir()
+			dataFile := filepath.Join(dir, "traffic_data.json")
+
+			legacy, err := newJSONStore(dataFile)
+			if err != nil {
+				t.Fatalf("newJSONStore() error = %v", err)
+			}
+			ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			// The first PutSample only establishes the baseline reading (see
+			// jsonStore.PutSample); a second is needed for the accumulator to
+			// have a nonzero delta to migrate.
+			if err := legacy.PutSample("eth0", ts, 100, 200); err != nil {
+				t.Fatalf("PutSample() error = %v", err)
+			}
+			if err := legacy.PutSample("eth0", ts.Add(time.Minute), 150, 260); err != nil {
+				t.Fatalf("PutSample() error = %v", err)
+			}
+			if err := legacy.Close(); err != nil {
+				t.Fatalf("legacy.Close() error = %v", err)
+			}
+
+			config := &Config{DataFile: dataFile, StorageDriver: driver}
+			store, err := NewStore(config)
+			if err != nil {
+				t.Fatalf("NewStore(%q) error = %v", driver, err)
+			}
+			defer store.Close()
+
+			if err := migrateLegacyJSON(dataFile, store, "eth0"); err != nil {
+				t.Fatalf("migrateLegacyJSON() error = %v", err)
+			}
+
+			got, err := store.Get("eth0")
+			if err != nil {
+				t.Fatalf("store.Get() error = %v", err)
+			}
+			if got.TotalBytesSent != 50 || got.TotalBytesRecv != 60 {
+				t.Errorf("store.Get(\"eth0\") = %+v, want {50 60}", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Sample represents a single timestamped cumulative traffic reading
+type Sample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Sent      uint64    `json:"sent"`
+	Recv      uint64    `json:"recv"`
+}
+
+// Store abstracts the persistence layer for traffic samples so the sampling
+// loop and HTTP handlers don't need to know whether data lives in a flat
+// JSON file, BuntDB, or SQLite. Every method is scoped to a single interface
+// so multi-homed routers can track WAN/LAN accounting independently.
+type Store interface {
+	// Get returns iface's accumulated totals for the current reset period.
+	Get(iface string) (TrafficData, error)
+	// PutSample records a new raw cumulative-since-boot reading for iface,
+	// adding the delta since the last reading to its period accumulator.
+	// Implementations must treat a reading lower than the last one as a
+	// reboot or counter wrap rather than negative traffic (see deltaSince).
+	PutSample(iface string, ts time.Time, sent, recv uint64) error
+	// Range returns every sample for iface between from and to (inclusive), ordered by time.
+	Range(iface string, from, to time.Time) ([]Sample, error)
+	// Reset zeroes iface's period accumulator and clears its stored samples,
+	// mirroring a quota rollover, while keeping its last raw reading so the
+	// next PutSample's delta is computed correctly.
+	Reset(iface string) error
+	// Prune deletes raw samples older than before across all interfaces,
+	// used by the retention compactor to enforce the raw retention window.
+	Prune(before time.Time) error
+	// PutRollup persists a single hourly rollup point for iface, computed by
+	// the retention compactor from raw samples before they're pruned, so
+	// coarse history survives past the raw retention window.
+	PutRollup(iface string, bucketStart time.Time, sent, recv uint64) error
+	// RangeRollups returns every persisted rollup point for iface between
+	// from and to (inclusive), ordered by time.
+	RangeRollups(iface string, from, to time.Time) ([]Sample, error)
+	// PruneRollups deletes rollup points older than before across all
+	// interfaces, used by the retention compactor to enforce the rollup
+	// retention window.
+	PruneRollups(before time.Time) error
+	// Close releases any underlying resources (file handles, DB connections).
+	Close() error
+}
+
+// NewStore creates a Store for the given config, selecting the backend via
+// config.StorageDriver ("json", "buntdb", or "sqlite"; "json" is the default).
+//
+// Non-"json" drivers never open config.DataFile directly: that path is the
+// legacy JSON file migrateLegacyJSON reads from, and opening it as a BuntDB
+// or SQLite file would either corrupt it or fail to parse. Instead their
+// store file is derived from DataFile with a driver-specific suffix, so the
+// old file is left untouched for the migration step that follows NewStore.
+func NewStore(config *Config) (Store, error) {
+	switch config.StorageDriver {
+	case "", "json":
+		return newJSONStore(config.DataFile)
+	case "buntdb":
+		return newBuntStore(storeFilePath(config.DataFile, "buntdb"))
+	case "sqlite":
+		return newSQLiteStore(storeFilePath(config.DataFile, "sqlite"))
+	default:
+		return nil, fmt.Errorf("unknown storage_driver %q", config.StorageDriver)
+	}
+}
+
+// storeFilePath derives a driver-specific store file from the configured
+// DataFile so it never collides with the legacy JSON file of the same name.
+func storeFilePath(dataFile, driver string) string {
+	return dataFile + "." + driver
+}
+
+// bucketSamples aggregates samples into fixed-size time buckets, taking the
+// latest cumulative reading observed in each bucket. Used both by /history to
+// answer bucketed queries on demand, and by the retention compactor to
+// compute the hourly rollups it persists before raw samples are pruned.
+func bucketSamples(samples []Sample, bucket time.Duration) []Sample {
+	if bucket <= 0 || len(samples) == 0 {
+		return samples
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+
+	var buckets []Sample
+	bucketStart := samples[0].Timestamp.Truncate(bucket)
+	var last Sample
+	for i, s := range samples {
+		if s.Timestamp.Sub(bucketStart) >= bucket {
+			buckets = append(buckets, last)
+			bucketStart = s.Timestamp.Truncate(bucket)
+		}
+		last = s
+		if i == len(samples)-1 {
+			buckets = append(buckets, last)
+		}
+	}
+	return buckets
+}
+
+// mergeSamples combines raw and rollup samples into a single time-ordered
+// slice, for /history queries that span both the raw retention window and
+// the coarser rollup tier behind it.
+func mergeSamples(raw, rollups []Sample) []Sample {
+	if len(rollups) == 0 {
+		return raw
+	}
+	if len(raw) == 0 {
+		return rollups
+	}
+
+	merged := make([]Sample, 0, len(raw)+len(rollups))
+	merged = append(merged, raw...)
+	merged = append(merged, rollups...)
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(merged[j].Timestamp)
+	})
+	return merged
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tidwall/buntdb"
+)
+
+// buntStore persists samples in an embedded BuntDB database, keyed so a
+// lexicographic scan per interface is also a time-ordered scan. Like
+// jsonStore, it tracks each interface's last raw reading ("state:<iface>")
+// separately from a monotonic accumulator of deltas between readings
+// ("accum:<iface>"), so a reboot or counter wrap never double-counts or
+// drops traffic. See jsonStore and deltaSince for the rationale.
+type buntStore struct {
+	db *buntdb.DB
+}
+
+func newBuntStore(dataFile string) (*buntStore, error) {
+	db, err := buntdb.Open(dataFile)
+	if err != nil {
+		return nil, fmt.Errorf("error opening buntdb store %s: %v", dataFile, err)
+	}
+	return &buntStore{db: db}, nil
+}
+
+func (s *buntStore) samplePrefix(iface string) string {
+	return "sample:" + iface + ":"
+}
+
+func (s *buntStore) sampleKey(iface string, ts time.Time) string {
+	return s.samplePrefix(iface) + ts.Format(time.RFC3339Nano)
+}
+
+func (s *buntStore) stateKey(iface string) string {
+	return "state:" + iface
+}
+
+func (s *buntStore) accumKey(iface string) string {
+	return "accum:" + iface
+}
+
+func (s *buntStore) rollupPrefix(iface string) string {
+	return "rollup:" + iface + ":"
+}
+
+func (s *buntStore) rollupKey(iface string, ts time.Time) string {
+	return s.rollupPrefix(iface) + ts.Format(time.RFC3339Nano)
+}
+
+func (s *buntStore) Get(iface string) (TrafficData, error) {
+	var accum TrafficData
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		val, err := tx.Get(s.accumKey(iface))
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+		return json.Unmarshal([]byte(val), &accum)
+	})
+	return accum, err
+}
+
+func (s *buntStore) PutSample(iface string, ts time.Time, sent, recv uint64) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		var last TrafficData
+		if val, err := tx.Get(s.stateKey(iface)); err == nil {
+			if err := json.Unmarshal([]byte(val), &last); err != nil {
+				return err
+			}
+		} else if err != buntdb.ErrNotFound {
+			return err
+		}
+
+		var accum TrafficData
+		if val, err := tx.Get(s.accumKey(iface)); err == nil {
+			if err := json.Unmarshal([]byte(val), &accum); err != nil {
+				return err
+			}
+		} else if err != buntdb.ErrNotFound {
+			return err
+		}
+		accum.TotalBytesSent += deltaSince(last.TotalBytesSent, sent)
+		accum.TotalBytesRecv += deltaSince(last.TotalBytesRecv, recv)
+
+		accumValue, err := json.Marshal(accum)
+		if err != nil {
+			return err
+		}
+		if _, _, err := tx.Set(s.accumKey(iface), string(accumValue), nil); err != nil {
+			return err
+		}
+
+		stateValue, err := json.Marshal(TrafficData{TotalBytesSent: sent, TotalBytesRecv: recv})
+		if err != nil {
+			return err
+		}
+		if _, _, err := tx.Set(s.stateKey(iface), string(stateValue), nil); err != nil {
+			return err
+		}
+
+		_, _, err = tx.Set(s.sampleKey(iface, ts), string(accumValue), nil)
+		return err
+	})
+}
+
+func (s *buntStore) Range(iface string, from, to time.Time) ([]Sample, error) {
+	var samples []Sample
+	prefix := s.samplePrefix(iface)
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		return tx.AscendKeys(prefix+"*", func(key, value string) bool {
+			ts, err := time.Parse(time.RFC3339Nano, key[len(prefix):])
+			if err != nil {
+				return true
+			}
+			if ts.Before(from) || ts.After(to) {
+				return true
+			}
+			var data TrafficData
+			if err := json.Unmarshal([]byte(value), &data); err != nil {
+				return true
+			}
+			samples = append(samples, Sample{Timestamp: ts, Sent: data.TotalBytesSent, Recv: data.TotalBytesRecv})
+			return true
+		})
+	})
+	return samples, err
+}
+
+func (s *buntStore) Reset(iface string) error {
+	// Zero the period accumulator but keep the last raw state reading so the
+	// next sample's delta is computed against it rather than against zero.
+	prefix := s.samplePrefix(iface)
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		if _, err := tx.Delete(s.accumKey(iface)); err != nil && err != buntdb.ErrNotFound {
+			return err
+		}
+
+		var keys []string
+		tx.AscendKeys(prefix+"*", func(key, value string) bool {
+			keys = append(keys, key)
+			return true
+		})
+		for _, key := range keys {
+			if _, err := tx.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *buntStore) Prune(before time.Time) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		var stale []string
+		tx.AscendKeys("sample:*", func(key, value string) bool {
+			// The RFC3339 timestamp itself contains colons, so split after
+			// the interface name rather than at the last colon.
+			rest := strings.TrimPrefix(key, "sample:")
+			sep := strings.Index(rest, ":")
+			if sep < 0 {
+				return true
+			}
+			ts, err := time.Parse(time.RFC3339Nano, rest[sep+1:])
+			if err == nil && ts.Before(before) {
+				stale = append(stale, key)
+			}
+			return true
+		})
+		for _, key := range stale {
+			if _, err := tx.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *buntStore) PutRollup(iface string, bucketStart time.Time, sent, recv uint64) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		value, err := json.Marshal(TrafficData{TotalBytesSent: sent, TotalBytesRecv: recv})
+		if err != nil {
+			return err
+		}
+		_, _, err = tx.Set(s.rollupKey(iface, bucketStart), string(value), nil)
+		return err
+	})
+}
+
+func (s *buntStore) RangeRollups(iface string, from, to time.Time) ([]Sample, error) {
+	var samples []Sample
+	prefix := s.rollupPrefix(iface)
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		return tx.AscendKeys(prefix+"*", func(key, value string) bool {
+			ts, err := time.Parse(time.RFC3339Nano, key[len(prefix):])
+			if err != nil {
+				return true
+			}
+			if ts.Before(from) || ts.After(to) {
+				return true
+			}
+			var data TrafficData
+			if err := json.Unmarshal([]byte(value), &data); err != nil {
+				return true
+			}
+			samples = append(samples, Sample{Timestamp: ts, Sent: data.TotalBytesSent, Recv: data.TotalBytesRecv})
+			return true
+		})
+	})
+	return samples, err
+}
+
+func (s *buntStore) PruneRollups(before time.Time) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		var stale []string
+		tx.AscendKeys("rollup:*", func(key, value string) bool {
+			rest := strings.TrimPrefix(key, "rollup:")
+			sep := strings.Index(rest, ":")
+			if sep < 0 {
+				return true
+			}
+			ts, err := time.Parse(time.RFC3339Nano, rest[sep+1:])
+			if err == nil && ts.Before(before) {
+				stale = append(stale, key)
+			}
+			return true
+		})
+		for _, key := range stale {
+			if _, err := tx.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *buntStore) Close() error {
+	return s.db.Close()
+}
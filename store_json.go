@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// jsonStore is the default Store implementation and the one TrafficData.go
+// has always shipped with: traffic_data.json holds a TrafficRecords map.
+//
+// Network interface counters are raw, monotonically-increasing-until-reboot
+// values from the kernel, so they can't just be read and stored directly:
+// a reboot (or, on 32-bit kernels, a counter wrap) resets them to near zero,
+// which would otherwise look like traffic vanishing or, if summed against
+// prior readings, like it was double-counted. jsonStore instead tracks, per
+// interface, the last raw reading it saw ("state::<iface>") and a monotonic
+// accumulator of deltas between readings ("accum::<iface>"). A reading lower
+// than the last one is treated as a reboot/wrap: the new raw value itself is
+// added as the delta, since the counter restarted from zero. Each tick also
+// appends a "sample::<iface>::<timestamp>" entry recording the accumulator's
+// value at that point in time, for /history.
+type jsonStore struct {
+	dataFile string
+	records  TrafficRecords
+}
+
+const (
+	jsonKeySeparator = "::"
+	samplePrefix     = "sample" + jsonKeySeparator
+	statePrefix      = "state" + jsonKeySeparator
+	accumPrefix      = "accum" + jsonKeySeparator
+	rollupPrefix     = "rollup" + jsonKeySeparator
+)
+
+func newJSONStore(dataFile string) (*jsonStore, error) {
+	records, err := loadOrCreateTrafficData(dataFile)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonStore{dataFile: dataFile, records: records}, nil
+}
+
+func sampleKey(iface string, ts time.Time) string {
+	return samplePrefix + iface + jsonKeySeparator + ts.Format(time.RFC3339Nano)
+}
+
+func rollupKey(iface string, ts time.Time) string {
+	return rollupPrefix + iface + jsonKeySeparator + ts.Format(time.RFC3339Nano)
+}
+
+// parseRollupKey splits a "rollup::<ifname>::<timestamp>" key, returning
+// ok=false for anything else.
+func parseRollupKey(key string) (iface string, ts time.Time, ok bool) {
+	if !strings.HasPrefix(key, rollupPrefix) {
+		return "", time.Time{}, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(key, rollupPrefix), jsonKeySeparator, 2)
+	if len(parts) != 2 {
+		return "", time.Time{}, false
+	}
+	ts, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return parts[0], ts, true
+}
+
+func stateKey(iface string) string {
+	return statePrefix + iface
+}
+
+func accumKey(iface string) string {
+	return accumPrefix + iface
+}
+
+// parseSampleKey splits a "sample::<ifname>::<timestamp>" key, returning
+// ok=false for anything else (a state/accum key, or a stray entry).
+func parseSampleKey(key string) (iface string, ts time.Time, ok bool) {
+	if !strings.HasPrefix(key, samplePrefix) {
+		return "", time.Time{}, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(key, samplePrefix), jsonKeySeparator, 2)
+	if len(parts) != 2 {
+		return "", time.Time{}, false
+	}
+	ts, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return parts[0], ts, true
+}
+
+func (s *jsonStore) Get(iface string) (TrafficData, error) {
+	return s.records[accumKey(iface)], nil
+}
+
+func (s *jsonStore) PutSample(iface string, ts time.Time, sent, recv uint64) error {
+	last, hadLast := s.records[stateKey(iface)]
+
+	var deltaSent, deltaRecv uint64
+	if hadLast {
+		deltaSent = deltaSince(last.TotalBytesSent, sent)
+		deltaRecv = deltaSince(last.TotalBytesRecv, recv)
+	}
+
+	accum := s.records[accumKey(iface)]
+	accum.TotalBytesSent += deltaSent
+	accum.TotalBytesRecv += deltaRecv
+	s.records[accumKey(iface)] = accum
+	s.records[stateKey(iface)] = TrafficData{TotalBytesSent: sent, TotalBytesRecv: recv}
+	s.records[sampleKey(iface, ts)] = accum
+
+	return saveTrafficData(s.dataFile, s.records)
+}
+
+// deltaSince returns how many bytes were added since the last raw reading.
+// A reading lower than the last one means the interface counter was reset by
+// a reboot or wrapped, so the new reading is itself the delta.
+func deltaSince(last, current uint64) uint64 {
+	if current >= last {
+		return current - last
+	}
+	return current
+}
+
+func (s *jsonStore) Range(iface string, from, to time.Time) ([]Sample, error) {
+	var samples []Sample
+	for key, data := range s.records {
+		ifaceKey, ts, ok := parseSampleKey(key)
+		if !ok || ifaceKey != iface {
+			continue
+		}
+		if ts.Before(from) || ts.After(to) {
+			continue
+		}
+		samples = append(samples, Sample{Timestamp: ts, Sent: data.TotalBytesSent, Recv: data.TotalBytesRecv})
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+	return samples, nil
+}
+
+func (s *jsonStore) Reset(iface string) error {
+	// Zero the period accumulator but keep the last raw state reading so the
+	// next sample's delta is computed against it rather than against zero.
+	delete(s.records, accumKey(iface))
+	for key := range s.records {
+		if ifaceKey, _, ok := parseSampleKey(key); ok && ifaceKey == iface {
+			delete(s.records, key)
+		}
+	}
+
+	if err := saveTrafficData(s.dataFile, s.records); err != nil {
+		return fmt.Errorf("error saving traffic data: %v", err)
+	}
+	return nil
+}
+
+func (s *jsonStore) Prune(before time.Time) error {
+	for key := range s.records {
+		_, ts, ok := parseSampleKey(key)
+		if !ok {
+			continue
+		}
+		if ts.Before(before) {
+			delete(s.records, key)
+		}
+	}
+	return saveTrafficData(s.dataFile, s.records)
+}
+
+func (s *jsonStore) PutRollup(iface string, bucketStart time.Time, sent, recv uint64) error {
+	s.records[rollupKey(iface, bucketStart)] = TrafficData{TotalBytesSent: sent, TotalBytesRecv: recv}
+	return saveTrafficData(s.dataFile, s.records)
+}
+
+func (s *jsonStore) RangeRollups(iface string, from, to time.Time) ([]Sample, error) {
+	var samples []Sample
+	for key, data := range s.records {
+		ifaceKey, ts, ok := parseRollupKey(key)
+		if !ok || ifaceKey != iface {
+			continue
+		}
+		if ts.Before(from) || ts.After(to) {
+			continue
+		}
+		samples = append(samples, Sample{Timestamp: ts, Sent: data.TotalBytesSent, Recv: data.TotalBytesRecv})
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+	return samples, nil
+}
+
+func (s *jsonStore) PruneRollups(before time.Time) error {
+	for key := range s.records {
+		_, ts, ok := parseRollupKey(key)
+		if !ok {
+			continue
+		}
+		if ts.Before(before) {
+			delete(s.records, key)
+		}
+	}
+	return saveTrafficData(s.dataFile, s.records)
+}
+
+func (s *jsonStore) Close() error {
+	return nil
+}
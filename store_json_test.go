@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeltaSince(t *testing.T) {
+	cases := []struct {
+		name          string
+		last, current uint64
+		want          uint64
+	}{
+		{"normal increase", 100, 150, 50},
+		{"no change", 100, 100, 0},
+		{"reboot or counter wrap", 1000, 20, 20},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := deltaSince(c.last, c.current); got != c.want {
+				t.Errorf("deltaSince(%d, %d) = %d, want %d", c.last, c.current, got, c.want)
+			}
+		})
+	}
+}
+
+func TestParseSampleKey(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	key := sampleKey("eth0", ts)
+
+	iface, gotTS, ok := parseSampleKey(key)
+	if !ok || iface != "eth0" || !gotTS.Equal(ts) {
+		t.Fatalf("parseSampleKey(%q) = (%q, %v, %v), want (\"eth0\", %v, true)", key, iface, gotTS, ok, ts)
+	}
+
+	if _, _, ok := parseSampleKey(stateKey("eth0")); ok {
+		t.Errorf("parseSampleKey(%q) should reject a state key", stateKey("eth0"))
+	}
+	if _, _, ok := parseSampleKey("garbage"); ok {
+		t.Errorf("parseSampleKey(\"garbage\") should reject a malformed key")
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteStore persists samples in a SQLite database, one row per interface
+// per tick, plus an interface_state table tracking each interface's last raw
+// reading and its monotonic accumulated total. See jsonStore and deltaSince
+// for why the accumulator, rather than the raw readings themselves, is what
+// /total and /history report.
+type sqliteStore struct {
+	db *sql.DB
+}
+
+func newSQLiteStore(dataFile string) (*sqliteStore, error) {
+	db, err := sql.Open("sqlite3", dataFile)
+	if err != nil {
+		return nil, fmt.Errorf("error opening sqlite store %s: %v", dataFile, err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS samples (
+			ifname TEXT NOT NULL,
+			ts     INTEGER NOT NULL,
+			sent   INTEGER NOT NULL,
+			recv   INTEGER NOT NULL,
+			PRIMARY KEY (ifname, ts)
+		);
+		CREATE TABLE IF NOT EXISTS interface_state (
+			ifname      TEXT PRIMARY KEY,
+			last_sent   INTEGER NOT NULL,
+			last_recv   INTEGER NOT NULL,
+			accum_sent  INTEGER NOT NULL,
+			accum_recv  INTEGER NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS rollups (
+			ifname TEXT NOT NULL,
+			ts     INTEGER NOT NULL,
+			sent   INTEGER NOT NULL,
+			recv   INTEGER NOT NULL,
+			PRIMARY KEY (ifname, ts)
+		);
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing sqlite schema: %v", err)
+	}
+
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) Get(iface string) (TrafficData, error) {
+	var data TrafficData
+	err := s.db.QueryRow(`SELECT accum_sent, accum_recv FROM interface_state WHERE ifname = ?`, iface).Scan(&data.TotalBytesSent, &data.TotalBytesRecv)
+	if err == sql.ErrNoRows {
+		return TrafficData{}, nil
+	}
+	return data, err
+}
+
+func (s *sqliteStore) PutSample(iface string, ts time.Time, sent, recv uint64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var lastSent, lastRecv, accumSent, accumRecv uint64
+	err = tx.QueryRow(`SELECT last_sent, last_recv, accum_sent, accum_recv FROM interface_state WHERE ifname = ?`, iface).
+		Scan(&lastSent, &lastRecv, &accumSent, &accumRecv)
+	if err != nil && err != sql.ErrNoRows {
+		tx.Rollback()
+		return err
+	}
+
+	accumSent += deltaSince(lastSent, sent)
+	accumRecv += deltaSince(lastRecv, recv)
+
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO interface_state (ifname, last_sent, last_recv, accum_sent, accum_recv) VALUES (?, ?, ?, ?, ?)`,
+		iface, sent, recv, accumSent, accumRecv); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO samples (ifname, ts, sent, recv) VALUES (?, ?, ?, ?)`,
+		iface, ts.UnixNano(), accumSent, accumRecv); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *sqliteStore) Range(iface string, from, to time.Time) ([]Sample, error) {
+	rows, err := s.db.Query(`SELECT ts, sent, recv FROM samples WHERE ifname = ? AND ts >= ? AND ts <= ? ORDER BY ts ASC`, iface, from.UnixNano(), to.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []Sample
+	for rows.Next() {
+		var tsNano int64
+		var sample Sample
+		if err := rows.Scan(&tsNano, &sample.Sent, &sample.Recv); err != nil {
+			return nil, err
+		}
+		sample.Timestamp = time.Unix(0, tsNano)
+		samples = append(samples, sample)
+	}
+	return samples, rows.Err()
+}
+
+func (s *sqliteStore) Reset(iface string) error {
+	// Zero the period accumulator but keep last_sent/last_recv so the next
+	// sample's delta is computed against the last raw reading, not zero.
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM samples WHERE ifname = ?`, iface); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE interface_state SET accum_sent = 0, accum_recv = 0 WHERE ifname = ?`, iface); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *sqliteStore) Prune(before time.Time) error {
+	_, err := s.db.Exec(`DELETE FROM samples WHERE ts < ?`, before.UnixNano())
+	return err
+}
+
+func (s *sqliteStore) PutRollup(iface string, bucketStart time.Time, sent, recv uint64) error {
+	_, err := s.db.Exec(`INSERT OR REPLACE INTO rollups (ifname, ts, sent, recv) VALUES (?, ?, ?, ?)`,
+		iface, bucketStart.UnixNano(), sent, recv)
+	return err
+}
+
+func (s *sqliteStore) RangeRollups(iface string, from, to time.Time) ([]Sample, error) {
+	rows, err := s.db.Query(`SELECT ts, sent, recv FROM rollups WHERE ifname = ? AND ts >= ? AND ts <= ? ORDER BY ts ASC`, iface, from.UnixNano(), to.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []Sample
+	for rows.Next() {
+		var tsNano int64
+		var sample Sample
+		if err := rows.Scan(&tsNano, &sample.Sent, &sample.Recv); err != nil {
+			return nil, err
+		}
+		sample.Timestamp = time.Unix(0, tsNano)
+		samples = append(samples, sample)
+	}
+	return samples, rows.Err()
+}
+
+func (s *sqliteStore) PruneRollups(before time.Time) error {
+	_, err := s.db.Exec(`DELETE FROM rollups WHERE ts < ?`, before.UnixNano())
+	return err
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketSamples(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := []Sample{
+		{Timestamp: base.Add(10 * time.Minute), Sent: 10, Recv: 20},
+		{Timestamp: base.Add(40 * time.Minute), Sent: 30, Recv: 40},
+		{Timestamp: base.Add(70 * time.Minute), Sent: 50, Recv: 60},
+	}
+
+	got := bucketSamples(samples, time.Hour)
+	if len(got) != 2 {
+		t.Fatalf("bucketSamples() returned %d buckets, want 2: %+v", len(got), got)
+	}
+	if got[0].Sent != 30 || got[1].Sent != 50 {
+		t.Errorf("bucketSamples() = %+v, want last sample of each hour (30, 50)", got)
+	}
+}
+
+func TestBucketSamplesNoOp(t *testing.T) {
+	samples := []Sample{{Timestamp: time.Now(), Sent: 1, Recv: 2}}
+	if got := bucketSamples(samples, 0); len(got) != len(samples) {
+		t.Errorf("bucketSamples() with bucket<=0 should return samples unchanged")
+	}
+	if got := bucketSamples(nil, time.Hour); got != nil {
+		t.Errorf("bucketSamples(nil, ...) = %+v, want nil", got)
+	}
+}